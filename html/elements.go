@@ -3,6 +3,7 @@ package html
 import (
 	"bytes"
 	"fmt"
+	stdhtml "html"
 	"html/template"
 	"io"
 )
@@ -21,6 +22,18 @@ type Value struct {
 	Value interface{}
 }
 
+// Raw returns a Block that writes s to the output verbatim, without
+// HTML-escaping. Use it when the caller has already produced safe
+// markup and rendering it as a Text value would double-escape it.
+func Raw(s string) Block {
+	return rawBlock(s)
+}
+
+type rawBlock string
+
+func (rawBlock) RenderHTML() Block      { return nil }
+func (b rawBlock) renderString() string { return string(b) }
+
 func (Text) RenderHTML() Block       { return nil }
 func (Comment) RenderHTML() Block    { return nil }
 func (CSS) RenderHTML() Block        { return nil }
@@ -56,11 +69,7 @@ type stringRenderer interface {
 }
 
 func Render(root Block, w io.Writer) error {
-	err := renderHTML(root, w, &renderCtx{})
-	if err != nil {
-		return err
-	}
-	return nil
+	return RenderWith(root, w)
 }
 
 func RenderMinified(root Block, w io.Writer) error {
@@ -73,8 +82,7 @@ func RenderMinified(root Block, w io.Writer) error {
 
 func RenderString(root Block) (string, error) {
 	buf := bytes.Buffer{}
-	err := renderHTML(root, &buf, &renderCtx{})
-	if err != nil {
+	if err := RenderWith(root, &buf); err != nil {
 		return "", err
 	}
 	return buf.String(), nil
@@ -93,6 +101,7 @@ type renderCtx struct {
 	level    int
 	item     int
 	minified bool
+	options  RendererOptions
 }
 
 func (c *renderCtx) enter() (item int) {
@@ -111,50 +120,84 @@ func (c *renderCtx) exit(item int) {
 	c.item = item
 }
 
+func writeIndentedLine(w io.Writer, ctx *renderCtx, s string) {
+	if !ctx.minified {
+		w.Write(bytes.Repeat([]byte{' '}, ctx.level*indentation))
+	}
+	w.Write([]byte(s))
+	if !ctx.minified {
+		w.Write([]byte{'\n'})
+	}
+}
+
 func renderHTML(c Block, w io.Writer, ctx *renderCtx) error {
 	//var item int
 	switch el := c.(type) {
 	case Text:
-		if !ctx.minified {
-			w.Write(bytes.Repeat([]byte{' '}, ctx.level*indentation))
+		writeIndentedLine(w, ctx, stdhtml.EscapeString(string(el)))
+		ctx.next()
+	case HTML:
+		if ctx.options.SkipHTML {
+			ctx.next()
+			return nil
 		}
-		w.Write([]byte(el))
-		if !ctx.minified {
-			w.Write([]byte{'\n'})
+		if s, ok := ctx.options.sanitize("html", string(el)); ok {
+			writeIndentedLine(w, ctx, s)
 		}
 		ctx.next()
-	case stringRenderer:
-		if !ctx.minified {
-			w.Write(bytes.Repeat([]byte{' '}, ctx.level*indentation))
+	case CSS:
+		if s, ok := ctx.options.sanitize("css", string(el)); ok {
+			writeIndentedLine(w, ctx, s)
 		}
-		w.Write([]byte(el.renderString()))
-		if !ctx.minified {
-			w.Write([]byte{'\n'})
+		ctx.next()
+	case URL:
+		if s, ok := ctx.options.sanitize("url", string(el)); ok {
+			writeIndentedLine(w, ctx, s)
 		}
 		ctx.next()
-	case Comment:
-		if !ctx.minified {
-			w.Write(bytes.Repeat([]byte{' '}, ctx.level*indentation))
+	case JS:
+		if s, ok := ctx.options.sanitize("js", string(el)); ok {
+			writeIndentedLine(w, ctx, s)
 		}
-		w.Write([]byte("<!--" + el + "-->"))
-		if !ctx.minified {
-			w.Write([]byte{'\n'})
+		ctx.next()
+	case JSStr:
+		if s, ok := ctx.options.sanitize("js", string(el)); ok {
+			writeIndentedLine(w, ctx, s)
 		}
 		ctx.next()
+	case stringRenderer:
+		writeIndentedLine(w, ctx, el.renderString())
+		ctx.next()
+	case Comment:
+		writeIndentedLine(w, ctx, "<!--"+string(el)+"-->")
+		ctx.next()
 	case Element:
+		if el.Type == "img" && ctx.options.SkipImages {
+			ctx.next()
+			return nil
+		}
 		if !ctx.minified {
 			w.Write(bytes.Repeat([]byte{' '}, ctx.level*indentation))
 		}
 		var attr string
-		for _, v := range el.Attr {
-			if v.Value == nil {
+		for _, v := range ctx.options.resolveAttrs(el) {
+			value, bare, omit := ctx.renderAttrValue(v.Key, v.Value)
+			if omit {
+				continue
+			}
+			if bare {
 				attr += " " + v.Key
 				continue
 			}
-			attr += " " + v.Key + "=" + fmt.Sprintf("%#v", v.Value)
+			attr += " " + v.Key + `="` + value + `"`
+		}
+		selfClose := el.Options&SelfClose != 0
+		isVoid := el.Options&Void != 0
+		if ctx.options.UseXHTML && isVoid {
+			selfClose = true
 		}
 		w.Write([]byte("<" + el.Type + attr))
-		if el.Options&SelfClose != 0 {
+		if selfClose {
 			w.Write([]byte("/>"))
 		} else {
 			w.Write([]byte(">"))
@@ -169,7 +212,7 @@ func renderHTML(c Block, w io.Writer, ctx *renderCtx) error {
 			}
 			ctx.exit(item)
 		}
-		if el.Options&Void+el.Options&SelfClose == 0 {
+		if !isVoid && !selfClose {
 			if !ctx.minified {
 				w.Write(bytes.Repeat([]byte{' '}, ctx.level*indentation))
 			}
@@ -282,6 +325,57 @@ func H2(attr Attr, children ...Block) Block {
 func H3(attr Attr, children ...Block) Block {
 	return makeElement("h3", attr, children, 0)
 }
+func P(attr Attr, children ...Block) Block {
+	return makeElement("p", attr, children, 0)
+}
+func Ul(attr Attr, children ...Block) Block {
+	return makeElement("ul", attr, children, 0)
+}
+func Ol(attr Attr, children ...Block) Block {
+	return makeElement("ol", attr, children, 0)
+}
+func Li(attr Attr, children ...Block) Block {
+	return makeElement("li", attr, children, 0)
+}
+func Pre(attr Attr, children ...Block) Block {
+	return makeElement("pre", attr, children, 0)
+}
+func Code(attr Attr, children ...Block) Block {
+	return makeElement("code", attr, children, 0)
+}
+func Blockquote(attr Attr, children ...Block) Block {
+	return makeElement("blockquote", attr, children, 0)
+}
+func Img(attr Attr, children ...Block) Block {
+	return makeElement("img", attr, children, Void)
+}
+func Hr(attr Attr, children ...Block) Block {
+	return makeElement("hr", attr, children, Void)
+}
+func Em(attr Attr, children ...Block) Block {
+	return makeElement("em", attr, children, 0)
+}
+func Strong(attr Attr, children ...Block) Block {
+	return makeElement("strong", attr, children, 0)
+}
+func Table(attr Attr, children ...Block) Block {
+	return makeElement("table", attr, children, 0)
+}
+func Thead(attr Attr, children ...Block) Block {
+	return makeElement("thead", attr, children, 0)
+}
+func Tbody(attr Attr, children ...Block) Block {
+	return makeElement("tbody", attr, children, 0)
+}
+func Tr(attr Attr, children ...Block) Block {
+	return makeElement("tr", attr, children, 0)
+}
+func Th(attr Attr, children ...Block) Block {
+	return makeElement("th", attr, children, 0)
+}
+func Td(attr Attr, children ...Block) Block {
+	return makeElement("td", attr, children, 0)
+}
 
 func makeElement(el string, attr Attr, children []Block, opt Option) Block {
 	if len(children) == 0 {
@@ -291,4 +385,4 @@ func makeElement(el string, attr Attr, children []Block, opt Option) Block {
 		return Element{el, attr, children, opt}
 	}
 	return Element{el, attr, Blocks(children), opt}
-}
\ No newline at end of file
+}
@@ -0,0 +1,109 @@
+package html
+
+import (
+	"fmt"
+	stdhtml "html"
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// renderAttrValue converts an AttrPair's Value into the string that
+// should be written into the attribute list. key is the attribute name,
+// needed to apply Safelink to href/src. bare reports that the attribute
+// has no value and should be written as a bare token (e.g. "disabled");
+// omit reports that the attribute should not be written at all (e.g. a
+// false boolean attribute, or typed content the renderer's
+// sanitization/Safelink rules rejected).
+func (ctx *renderCtx) renderAttrValue(key string, v interface{}) (value string, bare bool, omit bool) {
+	switch val := v.(type) {
+	case nil:
+		return "", true, false
+	case bool:
+		if !val {
+			return "", false, true
+		}
+		return "", true, false
+	case string:
+		return stdhtml.EscapeString(val), false, false
+	case int:
+		return strconv.Itoa(val), false, false
+	case int64:
+		return strconv.FormatInt(val, 10), false, false
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64), false, false
+	case HTMLAttr:
+		if ctx.options.SkipHTML {
+			return "", false, true
+		}
+		return string(val), false, false
+	case URL:
+		if (key == "href" || key == "src") && ctx.options.Safelink && !isSafeURL(string(val)) {
+			return "", false, true
+		}
+		s, ok := ctx.options.sanitize("url", string(val))
+		if !ok {
+			return "", false, true
+		}
+		return stdhtml.EscapeString(s), false, false
+	case CSS:
+		s, ok := ctx.options.sanitize("css", string(val))
+		if !ok {
+			return "", false, true
+		}
+		return stdhtml.EscapeString(cssEscape(s)), false, false
+	case JS:
+		s, ok := ctx.options.sanitize("js", string(val))
+		if !ok {
+			return "", false, true
+		}
+		return template.JSEscapeString(s), false, false
+	case JSStr:
+		s, ok := ctx.options.sanitize("js", string(val))
+		if !ok {
+			return "", false, true
+		}
+		return template.JSEscapeString(s), false, false
+	case []string:
+		return stdhtml.EscapeString(strings.Join(val, " ")), false, false
+	case map[string]string:
+		return stdhtml.EscapeString(styleString(val)), false, false
+	default:
+		return stdhtml.EscapeString(fmt.Sprint(val)), false, false
+	}
+}
+
+// styleString turns an inline-style map into "key:value;key2:value2",
+// sorted by key so output is deterministic.
+func styleString(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		if b.Len() > 0 {
+			b.WriteByte(';')
+		}
+		b.WriteString(k)
+		b.WriteByte(':')
+		b.WriteString(m[k])
+	}
+	return b.String()
+}
+
+// cssEscape backslash-escapes characters that could end a CSS string or
+// break out of a style attribute.
+func cssEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', '"', '\'', '<', '>':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,115 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextEscaping(t *testing.T) {
+	s, err := RenderString(Div(NoAttr, Text(`<script>alert(1)</script>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(s, "<script>") {
+		t.Fatalf("expected script tag to be escaped, got: %s", s)
+	}
+}
+
+func TestAttrValueEscaping(t *testing.T) {
+	cases := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"quote-breakout", `"><script>alert(1)</script>`, `&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;`},
+		{"ampersand", `a&b`, `a&amp;b`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, err := RenderString(Div(Attr{{Key: "data-x", Value: c.value}}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(s, c.want) {
+				t.Fatalf("expected %q in output, got: %s", c.want, s)
+			}
+			if strings.Contains(s, `data-x="`+c.value.(string)+`"`) {
+				t.Fatalf("attribute value escaped into the raw payload: %s", s)
+			}
+		})
+	}
+}
+
+func TestBooleanAttrs(t *testing.T) {
+	s, err := RenderMinifiedString(Element{
+		Type:    "input",
+		Attr:    Attr{{Key: "disabled", Value: true}, {Key: "checked", Value: false}},
+		Options: Void,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(s, "disabled") {
+		t.Fatalf("expected a true boolean attribute present bare, got: %s", s)
+	}
+	if strings.Contains(s, "checked") {
+		t.Fatalf("expected a false boolean attribute omitted, got: %s", s)
+	}
+}
+
+func TestClassListAndStyleMap(t *testing.T) {
+	s, err := RenderMinifiedString(Element{Type: "div", Attr: Attr{
+		{Key: "class", Value: []string{"a", "b"}},
+		{Key: "style", Value: map[string]string{"color": "red", "display": "none"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(s, `class="a b"`) {
+		t.Fatalf("expected a space-joined class list, got: %s", s)
+	}
+	if !strings.Contains(s, `style="color:red;display:none"`) {
+		t.Fatalf("expected a sorted inline style map, got: %s", s)
+	}
+}
+
+func TestTypedContentRequiresSanitizer(t *testing.T) {
+	tree := Div(NoAttr, HTML(`<b>bold</b>`))
+
+	s, err := RenderString(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(s, "<b>bold</b>") {
+		t.Fatalf("expected raw HTML dropped with no Unsafe/Sanitizer, got: %s", s)
+	}
+
+	var buf strings.Builder
+	if err := RenderWith(tree, &buf, WithUnsafe(true)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "<b>bold</b>") {
+		t.Fatalf("expected raw HTML under Unsafe, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	err = RenderWith(tree, &buf, WithSanitizer(func(kind, raw string) (string, bool) {
+		return raw, kind == "html"
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "<b>bold</b>") {
+		t.Fatalf("expected raw HTML approved by Sanitizer, got: %s", buf.String())
+	}
+}
+
+func TestRawBypassesEscaping(t *testing.T) {
+	s, err := RenderString(Div(NoAttr, Raw("<b>trusted</b>")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(s, "<b>trusted</b>") {
+		t.Fatalf("expected Raw content to pass through verbatim, got: %s", s)
+	}
+}
@@ -0,0 +1,288 @@
+// Package feed renders syndication feeds - Atom 1.0, RSS 2.0, and JSON
+// Feed - reusing this module's Block authoring style. Entry bodies
+// accept arbitrary Block children, so the same Div(...)/P(...) trees
+// used to build a page can be embedded as an entry's content.
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/neodigm/blocks/html"
+)
+
+// Feed is the top-level container rendered by RenderAtom, RenderRSS, and
+// RenderJSONFeed. It never produces HTML on its own - it is a
+// syndication-only Block.
+type Feed struct {
+	Title        string
+	Description  string
+	SelfURL      string // self link relation
+	AlternateURL string // alternate (html) link relation
+	ID           string // Atom id; defaults to SelfURL
+	Updated      time.Time
+	Author       *Author
+	Entries      []*Entry
+}
+
+func (Feed) RenderHTML() html.Block { return nil }
+
+// Entry is a single feed item. Body accepts arbitrary Block children so
+// the same tree authored for a page can be embedded as the entry's
+// content.
+type Entry struct {
+	Title     string
+	ID        string // GUID/id; defaults to URL
+	URL       string
+	Published time.Time
+	Updated   time.Time
+	Summary   string
+	Author    *Author
+	Body      html.Blocks
+}
+
+func (Entry) RenderHTML() html.Block { return nil }
+
+// Author identifies a feed or entry's author.
+type Author struct {
+	Name  string
+	Email string
+	URL   string
+}
+
+func (Author) RenderHTML() html.Block { return nil }
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// cdata wraps s in a CDATA section, splitting it if s itself contains
+// the CDATA terminator.
+func cdata(s string) string {
+	return "<![CDATA[" + strings.ReplaceAll(s, "]]>", "]]]]><![CDATA[>") + "]]>"
+}
+
+func entryID(e *Entry) string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.URL
+}
+
+func renderEntryBody(e *Entry) (string, error) {
+	if len(e.Body) == 0 {
+		return "", nil
+	}
+	return html.RenderMinifiedString(e.Body)
+}
+
+// RenderAtom writes f as an Atom 1.0 feed.
+func RenderAtom(f *Feed, w io.Writer) error {
+	fmt.Fprintln(w, `<?xml version="1.0" encoding="utf-8"?>`)
+	fmt.Fprintln(w, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	fmt.Fprintf(w, "  <title>%s</title>\n", xmlEscape(f.Title))
+	id := f.ID
+	if id == "" {
+		id = f.SelfURL
+	}
+	fmt.Fprintf(w, "  <id>%s</id>\n", xmlEscape(id))
+	fmt.Fprintf(w, "  <updated>%s</updated>\n", f.Updated.Format(time.RFC3339))
+	if f.SelfURL != "" {
+		fmt.Fprintf(w, "  <link rel=\"self\" href=\"%s\"/>\n", xmlEscape(f.SelfURL))
+	}
+	if f.AlternateURL != "" {
+		fmt.Fprintf(w, "  <link rel=\"alternate\" href=\"%s\"/>\n", xmlEscape(f.AlternateURL))
+	}
+	if f.Author != nil {
+		writeAtomAuthor(w, "  ", f.Author)
+	}
+	for _, e := range f.Entries {
+		if err := writeAtomEntry(w, e); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(w, `</feed>`)
+	return nil
+}
+
+func writeAtomAuthor(w io.Writer, indent string, a *Author) {
+	fmt.Fprintf(w, "%s<author>\n", indent)
+	fmt.Fprintf(w, "%s  <name>%s</name>\n", indent, xmlEscape(a.Name))
+	if a.Email != "" {
+		fmt.Fprintf(w, "%s  <email>%s</email>\n", indent, xmlEscape(a.Email))
+	}
+	if a.URL != "" {
+		fmt.Fprintf(w, "%s  <uri>%s</uri>\n", indent, xmlEscape(a.URL))
+	}
+	fmt.Fprintf(w, "%s</author>\n", indent)
+}
+
+func writeAtomEntry(w io.Writer, e *Entry) error {
+	fmt.Fprintln(w, `  <entry>`)
+	fmt.Fprintf(w, "    <title>%s</title>\n", xmlEscape(e.Title))
+	fmt.Fprintf(w, "    <id>%s</id>\n", xmlEscape(entryID(e)))
+	updated := e.Updated
+	if updated.IsZero() {
+		updated = e.Published
+	}
+	fmt.Fprintf(w, "    <updated>%s</updated>\n", updated.Format(time.RFC3339))
+	if !e.Published.IsZero() {
+		fmt.Fprintf(w, "    <published>%s</published>\n", e.Published.Format(time.RFC3339))
+	}
+	if e.URL != "" {
+		fmt.Fprintf(w, "    <link rel=\"alternate\" href=\"%s\"/>\n", xmlEscape(e.URL))
+	}
+	if e.Author != nil {
+		writeAtomAuthor(w, "    ", e.Author)
+	}
+	if e.Summary != "" {
+		fmt.Fprintf(w, "    <summary>%s</summary>\n", xmlEscape(e.Summary))
+	}
+	body, err := renderEntryBody(e)
+	if err != nil {
+		return err
+	}
+	if body != "" {
+		fmt.Fprintf(w, "    <content type=\"html\">%s</content>\n", xmlEscape(body))
+	}
+	fmt.Fprintln(w, `  </entry>`)
+	return nil
+}
+
+// RenderRSS writes f as an RSS 2.0 feed.
+func RenderRSS(f *Feed, w io.Writer) error {
+	fmt.Fprintln(w, `<?xml version="1.0" encoding="utf-8"?>`)
+	fmt.Fprintln(w, `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:content="http://purl.org/rss/1.0/modules/content/">`)
+	fmt.Fprintln(w, `  <channel>`)
+	fmt.Fprintf(w, "    <title>%s</title>\n", xmlEscape(f.Title))
+	if f.AlternateURL != "" {
+		fmt.Fprintf(w, "    <link>%s</link>\n", xmlEscape(f.AlternateURL))
+	}
+	fmt.Fprintf(w, "    <description>%s</description>\n", cdata(f.Description))
+	fmt.Fprintf(w, "    <lastBuildDate>%s</lastBuildDate>\n", f.Updated.Format(time.RFC1123Z))
+	if f.SelfURL != "" {
+		fmt.Fprintf(w, "    <atom:link rel=\"self\" href=\"%s\" type=\"application/rss+xml\"/>\n", xmlEscape(f.SelfURL))
+	}
+	for _, e := range f.Entries {
+		if err := writeRSSItem(w, e); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(w, `  </channel>`)
+	fmt.Fprintln(w, `</rss>`)
+	return nil
+}
+
+func writeRSSItem(w io.Writer, e *Entry) error {
+	fmt.Fprintln(w, `    <item>`)
+	fmt.Fprintf(w, "      <title>%s</title>\n", xmlEscape(e.Title))
+	if e.URL != "" {
+		fmt.Fprintf(w, "      <link>%s</link>\n", xmlEscape(e.URL))
+	}
+	if guid := entryID(e); guid != "" {
+		if guid == e.URL {
+			fmt.Fprintf(w, "      <guid>%s</guid>\n", xmlEscape(guid))
+		} else {
+			fmt.Fprintf(w, "      <guid isPermaLink=\"false\">%s</guid>\n", xmlEscape(guid))
+		}
+	}
+	pub := e.Published
+	if pub.IsZero() {
+		pub = e.Updated
+	}
+	if !pub.IsZero() {
+		fmt.Fprintf(w, "      <pubDate>%s</pubDate>\n", pub.Format(time.RFC1123Z))
+	}
+	if e.Author != nil && e.Author.Email != "" {
+		fmt.Fprintf(w, "      <author>%s</author>\n", xmlEscape(e.Author.Email))
+	}
+	if e.Summary != "" {
+		fmt.Fprintf(w, "      <description>%s</description>\n", cdata(e.Summary))
+	}
+	body, err := renderEntryBody(e)
+	if err != nil {
+		return err
+	}
+	if body != "" {
+		fmt.Fprintf(w, "      <content:encoded>%s</content:encoded>\n", cdata(body))
+	}
+	fmt.Fprintln(w, `    </item>`)
+	return nil
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type jsonFeedItem struct {
+	ID            string          `json:"id"`
+	URL           string          `json:"url,omitempty"`
+	Title         string          `json:"title,omitempty"`
+	Summary       string          `json:"summary,omitempty"`
+	ContentHTML   string          `json:"content_html,omitempty"`
+	DatePublished string          `json:"date_published,omitempty"`
+	DateModified  string          `json:"date_modified,omitempty"`
+	Author        *jsonFeedAuthor `json:"author,omitempty"`
+}
+
+type jsonFeedDoc struct {
+	Version     string          `json:"version"`
+	Title       string          `json:"title"`
+	HomePageURL string          `json:"home_page_url,omitempty"`
+	FeedURL     string          `json:"feed_url,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Author      *jsonFeedAuthor `json:"author,omitempty"`
+	Items       []jsonFeedItem  `json:"items"`
+}
+
+func jsonFeedAuthorOf(a *Author) *jsonFeedAuthor {
+	if a == nil {
+		return nil
+	}
+	return &jsonFeedAuthor{Name: a.Name, URL: a.URL}
+}
+
+// RenderJSONFeed writes f as a JSON Feed (version 1.1) document.
+func RenderJSONFeed(f *Feed, w io.Writer) error {
+	doc := jsonFeedDoc{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: f.AlternateURL,
+		FeedURL:     f.SelfURL,
+		Description: f.Description,
+		Author:      jsonFeedAuthorOf(f.Author),
+	}
+	for _, e := range f.Entries {
+		body, err := renderEntryBody(e)
+		if err != nil {
+			return err
+		}
+		item := jsonFeedItem{
+			ID:          entryID(e),
+			URL:         e.URL,
+			Title:       e.Title,
+			Summary:     e.Summary,
+			ContentHTML: body,
+			Author:      jsonFeedAuthorOf(e.Author),
+		}
+		if !e.Published.IsZero() {
+			item.DatePublished = e.Published.Format(time.RFC3339)
+		}
+		if !e.Updated.IsZero() {
+			item.DateModified = e.Updated.Format(time.RFC3339)
+		}
+		doc.Items = append(doc.Items, item)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
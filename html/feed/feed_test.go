@@ -0,0 +1,124 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neodigm/blocks/html"
+)
+
+func sampleFeed() *Feed {
+	return &Feed{
+		Title:        "Example & Friends",
+		Description:  "A test feed",
+		SelfURL:      `https://example.com/feed?a=1&b=2`,
+		AlternateURL: "https://example.com/",
+		Updated:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Entries: []*Entry{
+			{
+				Title:     "First post",
+				URL:       `https://example.com/posts/1?x=1&y=2"`,
+				Published: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				Summary:   "A summary",
+				Body:      html.Blocks{html.P(html.NoAttr, html.Text("hello"))},
+			},
+		},
+	}
+}
+
+func TestRenderAtomStructure(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderAtom(sampleFeed(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	s := buf.String()
+	if !strings.Contains(s, `<feed xmlns="http://www.w3.org/2005/Atom">`) {
+		t.Fatalf("expected an Atom root element, got: %s", s)
+	}
+	if !strings.Contains(s, "<title>Example &amp; Friends</title>") {
+		t.Fatalf("expected escaped feed title, got: %s", s)
+	}
+	if !strings.Contains(s, "<entry>") {
+		t.Fatalf("expected an entry, got: %s", s)
+	}
+}
+
+func TestRenderAtomEscapesHrefAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderAtom(sampleFeed(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	s := buf.String()
+	if strings.Contains(s, `href="https://example.com/feed?a=1&b=2"`) {
+		t.Fatalf("expected bare & in href to be XML-escaped, got: %s", s)
+	}
+	if !strings.Contains(s, `href="https://example.com/feed?a=1&amp;b=2"`) {
+		t.Fatalf("expected self link href to be XML-escaped, got: %s", s)
+	}
+	if !strings.Contains(s, `href="https://example.com/posts/1?x=1&amp;y=2&#34;"`) {
+		t.Fatalf("expected entry link href to be XML-escaped, got: %s", s)
+	}
+}
+
+func TestRenderRSSEscapesAtomLinkHref(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderRSS(sampleFeed(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	s := buf.String()
+	if !strings.Contains(s, `<atom:link rel="self" href="https://example.com/feed?a=1&amp;b=2" type="application/rss+xml"/>`) {
+		t.Fatalf("expected atom:link href to be XML-escaped, got: %s", s)
+	}
+	if !strings.Contains(s, "<![CDATA[A test feed]]>") {
+		t.Fatalf("expected CDATA-wrapped description, got: %s", s)
+	}
+}
+
+func TestRenderRSSGuidPermaLink(t *testing.T) {
+	f := sampleFeed()
+	f.Entries[0].ID = "urn:uuid:example-1"
+
+	var buf bytes.Buffer
+	if err := RenderRSS(f, &buf); err != nil {
+		t.Fatal(err)
+	}
+	s := buf.String()
+	if !strings.Contains(s, `<guid isPermaLink="false">urn:uuid:example-1</guid>`) {
+		t.Fatalf("expected isPermaLink=false for an opaque id distinct from the entry URL, got: %s", s)
+	}
+}
+
+func TestRenderRSSGuidDefaultsToPermaLink(t *testing.T) {
+	f := sampleFeed()
+	f.Entries[0].ID = ""
+
+	var buf bytes.Buffer
+	if err := RenderRSS(f, &buf); err != nil {
+		t.Fatal(err)
+	}
+	s := buf.String()
+	if strings.Contains(s, "isPermaLink") {
+		t.Fatalf("expected no isPermaLink attribute when guid equals the entry URL, got: %s", s)
+	}
+}
+
+func TestRenderJSONFeedStructure(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderJSONFeed(sampleFeed(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("expected valid JSON, got error %v: %s", err, buf.String())
+	}
+	if doc["version"] != "https://jsonfeed.org/version/1.1" {
+		t.Fatalf("expected JSON Feed version, got: %v", doc["version"])
+	}
+	items, ok := doc["items"].([]interface{})
+	if !ok || len(items) != 1 {
+		t.Fatalf("expected one item, got: %v", doc["items"])
+	}
+}
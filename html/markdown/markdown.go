@@ -0,0 +1,196 @@
+// Package markdown adapts Markdown source into this module's Block
+// tree, so long-form content can be authored in Markdown and still
+// composed with programmatic blocks and pushed through html.Render,
+// html.RenderMinified, or html.RenderText.
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+
+	"github.com/neodigm/blocks/html"
+)
+
+// Options controls how Parse translates a Markdown AST into a Block
+// tree.
+type Options struct {
+	// HardWraps turns a soft line break into a hard <br> break, the
+	// same behaviour goldmark's own HTML renderer offers.
+	HardWraps bool
+
+	// Unsafe allows raw HTML passthrough nodes to become html.HTML
+	// values. When false (the default) raw HTML is dropped.
+	Unsafe bool
+}
+
+// Parse converts Markdown source into a Block tree using this module's
+// constructors (H1-H3, P, Ul, A, ...).
+func Parse(src []byte) (html.Block, error) {
+	return ParseWithOptions(src, Options{})
+}
+
+// ParseString is a convenience wrapper over Parse for string input.
+func ParseString(src string) (html.Block, error) {
+	return Parse([]byte(src))
+}
+
+// ParseWithOptions is like Parse but accepts Options controlling hard
+// wraps and raw HTML passthrough.
+func ParseWithOptions(src []byte, opts Options) (html.Block, error) {
+	md := goldmark.New(goldmark.WithExtensions(extension.Table))
+	doc := md.Parser().Parse(text.NewReader(src))
+	c := &converter{src: src, opts: opts}
+	return c.children(doc), nil
+}
+
+type converter struct {
+	src  []byte
+	opts Options
+}
+
+func (c *converter) children(n ast.Node) html.Blocks {
+	var blocks html.Blocks
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		if b := c.convert(child); b != nil {
+			blocks.Add(b)
+		}
+	}
+	return blocks
+}
+
+func (c *converter) convert(n ast.Node) html.Block {
+	switch n := n.(type) {
+	case *ast.Heading:
+		children := c.children(n)
+		switch n.Level {
+		case 1:
+			return html.H1(html.NoAttr, children...)
+		case 2:
+			return html.H2(html.NoAttr, children...)
+		default:
+			return html.H3(html.NoAttr, children...)
+		}
+	case *ast.Paragraph, *ast.TextBlock:
+		return html.P(html.NoAttr, c.children(n)...)
+	case *ast.ThematicBreak:
+		return html.Hr(html.NoAttr)
+	case *ast.Blockquote:
+		return html.Blockquote(html.NoAttr, c.children(n)...)
+	case *ast.List:
+		items := c.children(n)
+		if n.IsOrdered() {
+			return html.Ol(html.NoAttr, items...)
+		}
+		return html.Ul(html.NoAttr, items...)
+	case *ast.ListItem:
+		return html.Li(html.NoAttr, c.children(n)...)
+	case *ast.CodeBlock:
+		return html.Pre(html.NoAttr, html.Code(html.NoAttr, html.Text(c.lines(n.Lines()))))
+	case *ast.FencedCodeBlock:
+		attr := html.NoAttr
+		if lang := n.Language(c.src); len(lang) > 0 {
+			attr = html.Attr{{Key: "class", Value: "language-" + string(lang)}}
+		}
+		return html.Pre(html.NoAttr, html.Code(attr, html.Text(c.lines(n.Lines()))))
+	case *ast.HTMLBlock:
+		return c.rawHTML(c.lines(n.Lines()))
+	case *ast.RawHTML:
+		return c.rawHTML(c.lines(n.Segments))
+	case *ast.Text:
+		s := string(n.Segment.Value(c.src))
+		if n.HardLineBreak() {
+			return html.Blocks{html.Text(s), br()}
+		}
+		if n.SoftLineBreak() {
+			if c.opts.HardWraps {
+				return html.Blocks{html.Text(s), br()}
+			}
+			return html.Blocks{html.Text(s), html.Text(" ")}
+		}
+		return html.Text(s)
+	case *ast.String:
+		return html.Text(string(n.Value))
+	case *ast.CodeSpan:
+		return html.Code(html.NoAttr, c.children(n)...)
+	case *ast.Emphasis:
+		if n.Level >= 2 {
+			return html.Strong(html.NoAttr, c.children(n)...)
+		}
+		return html.Em(html.NoAttr, c.children(n)...)
+	case *ast.Link:
+		return html.A(html.Attr{{Key: "href", Value: string(n.Destination)}}, c.children(n)...)
+	case *ast.AutoLink:
+		return html.A(html.Attr{{Key: "href", Value: string(n.URL(c.src))}}, html.Text(string(n.Label(c.src))))
+	case *ast.Image:
+		attr := html.Attr{{Key: "src", Value: string(n.Destination)}}
+		if alt := c.plainText(n); alt != "" {
+			attr = append(attr, html.AttrPair{Key: "alt", Value: alt})
+		}
+		return html.Img(attr)
+	case *east.Table:
+		var head html.Block
+		var bodyRows html.Blocks
+		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+			switch row := child.(type) {
+			case *east.TableHeader:
+				head = c.convert(row)
+			case *east.TableRow:
+				bodyRows.Add(c.convert(row))
+			}
+		}
+		return html.Table(html.NoAttr, html.Thead(html.NoAttr, head), html.Tbody(html.NoAttr, bodyRows...))
+	case *east.TableHeader:
+		var cells html.Blocks
+		for cell := n.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells.Add(html.Th(html.NoAttr, c.children(cell)...))
+		}
+		return html.Tr(html.NoAttr, cells...)
+	case *east.TableRow:
+		var cells html.Blocks
+		for cell := n.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells.Add(html.Td(html.NoAttr, c.children(cell)...))
+		}
+		return html.Tr(html.NoAttr, cells...)
+	default:
+		return nil
+	}
+}
+
+// rawHTML becomes an html.HTML value when the adapter was asked to
+// trust raw HTML passthrough, and is dropped otherwise.
+func (c *converter) rawHTML(raw string) html.Block {
+	if !c.opts.Unsafe || raw == "" {
+		return nil
+	}
+	return html.HTML(raw)
+}
+
+func (c *converter) lines(lines *text.Segments) string {
+	var buf bytes.Buffer
+	for i := 0; i < lines.Len(); i++ {
+		seg := lines.At(i)
+		buf.Write(seg.Value(c.src))
+	}
+	return buf.String()
+}
+
+func (c *converter) plainText(n ast.Node) string {
+	var buf bytes.Buffer
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		if t, ok := child.(*ast.Text); ok {
+			buf.Write(t.Segment.Value(c.src))
+			continue
+		}
+		buf.WriteString(c.plainText(child))
+	}
+	return buf.String()
+}
+
+func br() html.Block {
+	return html.Element{Type: "br", Options: html.Void}
+}
@@ -0,0 +1,94 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neodigm/blocks/html"
+)
+
+func renderToString(t *testing.T, root html.Block) string {
+	t.Helper()
+	s, err := html.RenderMinifiedString(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestParseBasicStructure(t *testing.T) {
+	root, err := ParseString("# Title\n\nHello **world**, [link](https://example.com)\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := renderToString(t, root)
+	if !strings.Contains(s, "<h1>Title</h1>") {
+		t.Fatalf("expected an h1, got: %s", s)
+	}
+	if !strings.Contains(s, "<strong>world</strong>") {
+		t.Fatalf("expected bold text, got: %s", s)
+	}
+	if !strings.Contains(s, `<a href="https://example.com">link</a>`) {
+		t.Fatalf("expected a link, got: %s", s)
+	}
+}
+
+func TestSoftLineBreaksBecomeSpaces(t *testing.T) {
+	root, err := ParseString("hello\nworld\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := renderToString(t, root)
+	if !strings.Contains(s, "hello world") {
+		t.Fatalf("expected a space to separate soft-wrapped lines, got: %s", s)
+	}
+}
+
+func TestHardWrapsBecomeBr(t *testing.T) {
+	root, err := ParseWithOptions([]byte("hello\nworld\n"), Options{HardWraps: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := renderToString(t, root)
+	if !strings.Contains(s, "<br/>") && !strings.Contains(s, "<br>") {
+		t.Fatalf("expected a hard break, got: %s", s)
+	}
+}
+
+func TestTableConversion(t *testing.T) {
+	root, err := ParseString("| A | B |\n|---|---|\n| 1 | 2 |\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := renderToString(t, root)
+	if !strings.Contains(s, "<thead>") || !strings.Contains(s, "<tbody>") {
+		t.Fatalf("expected thead/tbody, got: %s", s)
+	}
+	if !strings.Contains(s, "<th>A</th>") || !strings.Contains(s, "<td>1</td>") {
+		t.Fatalf("expected header and data cells, got: %s", s)
+	}
+}
+
+func TestRawHTMLDroppedUnlessUnsafe(t *testing.T) {
+	src := []byte("before\n\n<div>raw</div>\n\nafter\n")
+
+	root, err := ParseWithOptions(src, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(renderToString(t, root), "<div>raw</div>") {
+		t.Fatalf("expected raw HTML to be dropped when the adapter isn't told to trust it")
+	}
+
+	root, err = ParseWithOptions(src, Options{Unsafe: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	if err := html.RenderWith(root, &buf, html.WithUnsafe(true)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "<div>raw</div>") {
+		t.Fatalf("expected raw HTML to survive when both the adapter and renderer trust it, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,207 @@
+package html
+
+import (
+	"io"
+	"strings"
+)
+
+// Sanitizer is consulted for every typed HTML/CSS/JS/URL value when
+// Unsafe is false. kind is one of "html", "css", "js", "url" and raw is
+// the value about to be emitted. It returns the (possibly rewritten)
+// value to emit and whether emitting it is allowed at all.
+type Sanitizer func(kind string, raw string) (string, bool)
+
+// RendererOptions controls the cross-cutting safety and compatibility
+// behaviour of RenderWith, modeled on the flag sets used by gomarkdown
+// and goldmark's HTML renderers.
+type RendererOptions struct {
+	// Safelink only emits href/src attributes when the URL scheme is in
+	// a whitelist (http, https, mailto, tel); relative URLs are always
+	// allowed. Disallowed URLs have the attribute dropped.
+	Safelink bool
+
+	// NofollowLinks, NoreferrerLinks and NoopenerLinks append the
+	// corresponding token to an <a>'s rel attribute.
+	NofollowLinks   bool
+	NoreferrerLinks bool
+	NoopenerLinks   bool
+
+	// HrefTargetBlank forces target="_blank" (and rel="noopener") on
+	// <a> elements whose href points off-site (http:// or https://).
+	HrefTargetBlank bool
+
+	// SkipImages drops <img> elements entirely.
+	SkipImages bool
+
+	// SkipHTML strips HTML/HTMLAttr typed values instead of emitting
+	// them.
+	SkipHTML bool
+
+	// UseXHTML self-closes every Void element ("<br/>" instead of
+	// "<br>").
+	UseXHTML bool
+
+	// Unsafe allows HTML/CSS/JS/URL typed content to be emitted without
+	// consulting Sanitizer; SkipHTML and Safelink still apply. When false
+	// (the default), such content is only emitted if Sanitizer is set and
+	// approves it.
+	Unsafe bool
+
+	// Sanitizer is consulted for typed content when Unsafe is false.
+	Sanitizer Sanitizer
+}
+
+// RendererOption configures a RendererOptions value. Construct one with
+// the With* functions below and pass it to RenderWith.
+type RendererOption func(*RendererOptions)
+
+func WithSafelink(v bool) RendererOption { return func(o *RendererOptions) { o.Safelink = v } }
+
+func WithNofollowLinks(v bool) RendererOption {
+	return func(o *RendererOptions) { o.NofollowLinks = v }
+}
+
+func WithNoreferrerLinks(v bool) RendererOption {
+	return func(o *RendererOptions) { o.NoreferrerLinks = v }
+}
+
+func WithNoopenerLinks(v bool) RendererOption {
+	return func(o *RendererOptions) { o.NoopenerLinks = v }
+}
+
+func WithHrefTargetBlank(v bool) RendererOption {
+	return func(o *RendererOptions) { o.HrefTargetBlank = v }
+}
+
+func WithSkipImages(v bool) RendererOption { return func(o *RendererOptions) { o.SkipImages = v } }
+
+func WithSkipHTML(v bool) RendererOption { return func(o *RendererOptions) { o.SkipHTML = v } }
+
+func WithUseXHTML(v bool) RendererOption { return func(o *RendererOptions) { o.UseXHTML = v } }
+
+func WithUnsafe(v bool) RendererOption { return func(o *RendererOptions) { o.Unsafe = v } }
+
+func WithSanitizer(s Sanitizer) RendererOption {
+	return func(o *RendererOptions) { o.Sanitizer = s }
+}
+
+// sanitize gates emission of a typed HTML/CSS/JS/URL value. Unsafe
+// content passes through unchanged; otherwise the configured Sanitizer
+// decides, and content is dropped if none is configured.
+func (o *RendererOptions) sanitize(kind, raw string) (string, bool) {
+	if o.Unsafe {
+		return raw, true
+	}
+	if o.Sanitizer == nil {
+		return "", false
+	}
+	return o.Sanitizer(kind, raw)
+}
+
+var safeURLSchemes = []string{"http:", "https:", "mailto:", "tel:"}
+
+// attrURLString extracts the string form of an href/src attribute value,
+// regardless of whether it was authored as a plain string or as the
+// module's URL type.
+func attrURLString(v interface{}) (string, bool) {
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case URL:
+		return string(s), true
+	default:
+		return "", false
+	}
+}
+
+// isSafeURL reports whether raw is relative (no scheme) or uses one of
+// the whitelisted schemes above.
+func isSafeURL(raw string) bool {
+	i := strings.IndexAny(raw, ":/")
+	if i < 0 || raw[i] != ':' {
+		return true
+	}
+	scheme := strings.ToLower(raw[:i+1])
+	for _, s := range safeURLSchemes {
+		if scheme == s {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAttrs applies Safelink filtering and <a> rel/target merging to
+// el's attributes, returning the attribute list that should actually be
+// emitted.
+func (o *RendererOptions) resolveAttrs(el Element) Attr {
+	attrs := make(Attr, 0, len(el.Attr))
+	var rel []string
+	external := false
+	for _, a := range el.Attr {
+		if el.Type == "a" && a.Key == "rel" {
+			if s, ok := a.Value.(string); ok && s != "" {
+				rel = append(rel, strings.Fields(s)...)
+			}
+			continue
+		}
+		if (a.Key == "href" || a.Key == "src") && o.Safelink {
+			if s, ok := attrURLString(a.Value); ok && !isSafeURL(s) {
+				continue
+			}
+		}
+		attrs = append(attrs, a)
+		if el.Type == "a" && a.Key == "href" {
+			if s, ok := attrURLString(a.Value); ok && (strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")) {
+				external = true
+			}
+		}
+	}
+	if el.Type != "a" {
+		return attrs
+	}
+	if o.NofollowLinks {
+		rel = appendUniqueToken(rel, "nofollow")
+	}
+	if o.NoreferrerLinks {
+		rel = appendUniqueToken(rel, "noreferrer")
+	}
+	if o.NoopenerLinks || (o.HrefTargetBlank && external) {
+		rel = appendUniqueToken(rel, "noopener")
+	}
+	if o.HrefTargetBlank && external {
+		attrs = setAttr(attrs, "target", "_blank")
+	}
+	if len(rel) > 0 {
+		attrs = setAttr(attrs, "rel", strings.Join(rel, " "))
+	}
+	return attrs
+}
+
+func appendUniqueToken(tokens []string, v string) []string {
+	for _, t := range tokens {
+		if t == v {
+			return tokens
+		}
+	}
+	return append(tokens, v)
+}
+
+func setAttr(attrs Attr, key string, value interface{}) Attr {
+	for i, a := range attrs {
+		if a.Key == key {
+			attrs[i].Value = value
+			return attrs
+		}
+	}
+	return append(attrs, AttrPair{Key: key, Value: value})
+}
+
+// RenderWith walks root like Render, but applies the given options -
+// safe links, XHTML self-closing, HTML/CSS/JS sanitization, and so on.
+func RenderWith(root Block, w io.Writer, opts ...RendererOption) error {
+	options := RendererOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return renderHTML(root, w, &renderCtx{options: options})
+}
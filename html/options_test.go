@@ -0,0 +1,82 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func renderWith(t *testing.T, root Block, opts ...RendererOption) string {
+	t.Helper()
+	var buf strings.Builder
+	if err := RenderWith(root, &buf, opts...); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestSafelinkDropsDangerousSchemes(t *testing.T) {
+	for _, href := range []string{"javascript:alert(1)", "data:text/html,<script>alert(1)</script>"} {
+		a := A(Attr{{Key: "href", Value: href}}, Text("x"))
+		s := renderWith(t, a, WithSafelink(true))
+		if strings.Contains(s, href) {
+			t.Fatalf("expected %q to be dropped under Safelink, got: %s", href, s)
+		}
+	}
+}
+
+func TestSafelinkAllowsWhitelistedAndRelativeURLs(t *testing.T) {
+	for _, href := range []string{"https://example.com", "mailto:a@b.com", "/relative", "#frag"} {
+		a := A(Attr{{Key: "href", Value: href}}, Text("x"))
+		s := renderWith(t, a, WithSafelink(true))
+		if !strings.Contains(s, `href="`+href+`"`) {
+			t.Fatalf("expected %q to survive Safelink, got: %s", href, s)
+		}
+	}
+}
+
+func TestSafelinkAppliesToURLTypedHrefUnderUnsafe(t *testing.T) {
+	a := A(Attr{{Key: "href", Value: URL("javascript:alert(1)")}}, Text("x"))
+	s := renderWith(t, a, WithSafelink(true), WithUnsafe(true))
+	if strings.Contains(s, "javascript:") {
+		t.Fatalf("expected URL-typed href to be filtered by Safelink, got: %s", s)
+	}
+}
+
+func TestRelMerging(t *testing.T) {
+	a := A(Attr{{Key: "href", Value: "https://example.com"}, {Key: "rel", Value: "me"}}, Text("x"))
+	s := renderWith(t, a, WithNofollowLinks(true), WithNoreferrerLinks(true))
+	for _, token := range []string{"me", "nofollow", "noreferrer"} {
+		if !strings.Contains(s, token) {
+			t.Fatalf("expected rel to contain %q, got: %s", token, s)
+		}
+	}
+}
+
+func TestHrefTargetBlankOnlyAffectsExternalLinks(t *testing.T) {
+	external := A(Attr{{Key: "href", Value: "https://example.com"}}, Text("x"))
+	internal := A(Attr{{Key: "href", Value: "/about"}}, Text("x"))
+
+	s := renderWith(t, external, WithHrefTargetBlank(true))
+	if !strings.Contains(s, `target="_blank"`) || !strings.Contains(s, "noopener") {
+		t.Fatalf("expected target=_blank and noopener on external link, got: %s", s)
+	}
+
+	s = renderWith(t, internal, WithHrefTargetBlank(true))
+	if strings.Contains(s, "target=") {
+		t.Fatalf("expected no target on internal link, got: %s", s)
+	}
+}
+
+func TestSkipImages(t *testing.T) {
+	s := renderWith(t, Img(Attr{{Key: "src", Value: "/a.png"}}), WithSkipImages(true))
+	if strings.Contains(s, "<img") {
+		t.Fatalf("expected <img> to be skipped, got: %s", s)
+	}
+}
+
+func TestUseXHTMLSelfClosesVoidElements(t *testing.T) {
+	s := renderWith(t, Hr(NoAttr), WithUseXHTML(true))
+	if !strings.Contains(s, "<hr/>") {
+		t.Fatalf("expected self-closed void element, got: %s", s)
+	}
+}
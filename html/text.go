@@ -0,0 +1,388 @@
+package html
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextAlign controls how a table cell's contents are padded within its
+// column when PrettyTables renders an ASCII grid.
+type TextAlign int
+
+const (
+	AlignDefault TextAlign = iota
+	AlignLeft
+	AlignCenter
+	AlignRight
+)
+
+// TableBorders selects which edges of a PrettyTables grid get a rule
+// drawn around them.
+type TableBorders uint8
+
+const (
+	BorderLeft TableBorders = 1 << iota
+	BorderRight
+	BorderTop
+	BorderBottom
+)
+
+var AllBorders = BorderLeft | BorderRight | BorderTop | BorderBottom
+
+// TextOptions controls how RenderText walks a Block tree and converts it
+// into plain text suitable for terminals, email bodies, or gemini
+// conversion.
+type TextOptions struct {
+	// OmitLinks renders <a> children as bare text instead of the
+	// default "text (href)" form.
+	OmitLinks bool
+
+	// TextOnly emits tables as tab-separated rows rather than an ASCII
+	// grid, even when PrettyTables is set. It does not change how links
+	// are rendered; use OmitLinks for that.
+	TextOnly bool
+
+	// PrettyTables mirrors the idea of ncg's PrettyTablesOptions: when
+	// set, <table> elements are rendered as an ASCII grid using the
+	// separators and alignment below.
+	PrettyTables    bool
+	ColWidth        int
+	ColumnSeparator string
+	RowSeparator    string
+	CenterSeparator string
+	HeaderAlignment TextAlign
+	Borders         TableBorders
+}
+
+const defaultColWidth = 30
+
+func defaultTextOptions() *TextOptions {
+	return &TextOptions{
+		PrettyTables:    true,
+		ColWidth:        defaultColWidth,
+		ColumnSeparator: "|",
+		RowSeparator:    "-",
+		CenterSeparator: "+",
+		HeaderAlignment: AlignCenter,
+		Borders:         AllBorders,
+	}
+}
+
+// textBlockTags are elements RenderText surrounds with blank lines,
+// mirroring how a browser lays them out on their own line.
+var textBlockTags = map[string]bool{
+	"div":  true,
+	"h1":   true,
+	"h2":   true,
+	"h3":   true,
+	"main": true,
+}
+
+// textSuppressedTags never reach the plaintext output; their contents
+// don't make sense outside a browser.
+var textSuppressedTags = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
+// RenderText walks root the same way Render does, but emits plain text
+// suitable for terminals, email bodies, or gemini conversion instead of
+// HTML. <script>/<style> elements (and anything marked CSSElement or
+// JSElement) are skipped, block-level elements get surrounding blank
+// lines, and <table>/<thead>/<tbody>/<tr>/<td>/<th> are rendered through
+// an ASCII grid renderer when opts.PrettyTables is set.
+func RenderText(root Block, w io.Writer, opts *TextOptions) error {
+	if opts == nil {
+		opts = defaultTextOptions()
+	}
+	walker := &textWalker{opts: opts, w: w}
+	walker.walk(root)
+	walker.flushParagraph()
+	return nil
+}
+
+type textWalker struct {
+	opts     *TextOptions
+	w        io.Writer
+	para     strings.Builder
+	wroteAny bool
+}
+
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func (t *textWalker) flushParagraph() {
+	s := collapseWhitespace(t.para.String())
+	t.para.Reset()
+	if s == "" {
+		return
+	}
+	if t.wroteAny {
+		fmt.Fprint(t.w, "\n\n")
+	}
+	fmt.Fprint(t.w, s)
+	t.wroteAny = true
+}
+
+func (t *textWalker) walk(c Block) {
+	switch el := c.(type) {
+	case nil:
+		return
+	case Text:
+		t.para.WriteString(string(el))
+		t.para.WriteByte(' ')
+	case Comment:
+		// comments never reach plaintext output
+	case Element:
+		t.walkElement(el)
+	case Blocks:
+		for _, e := range el {
+			t.walk(e)
+		}
+	case stringRenderer:
+		t.para.WriteString(el.renderString())
+		t.para.WriteByte(' ')
+	case Block:
+		t.walk(el.RenderHTML())
+	}
+}
+
+func (t *textWalker) walkElement(el Element) {
+	if textSuppressedTags[el.Type] || el.Options&CSSElement != 0 || el.Options&JSElement != 0 {
+		return
+	}
+	switch el.Type {
+	case "table":
+		t.flushParagraph()
+		t.renderTable(el)
+		return
+	case "a":
+		t.walkAnchor(el)
+		return
+	}
+	block := textBlockTags[el.Type]
+	if block {
+		t.flushParagraph()
+	}
+	for _, child := range el.Children {
+		t.walk(child)
+	}
+	if block {
+		t.flushParagraph()
+	}
+}
+
+func (t *textWalker) walkAnchor(el Element) {
+	text := t.collectText(el.Children)
+	t.para.WriteString(text)
+	if !t.opts.OmitLinks {
+		if href, ok := attrString(el.Attr, "href"); ok && href != "" {
+			t.para.WriteString(" (" + href + ")")
+		}
+	}
+	t.para.WriteByte(' ')
+}
+
+func (t *textWalker) collectText(children Blocks) string {
+	inner := &textWalker{opts: t.opts}
+	for _, c := range children {
+		inner.walk(c)
+	}
+	return collapseWhitespace(inner.para.String())
+}
+
+func attrString(attr Attr, key string) (string, bool) {
+	for _, a := range attr {
+		if a.Key == key {
+			return attrURLString(a.Value)
+		}
+	}
+	return "", false
+}
+
+type textTableRow struct {
+	cells  []string
+	header bool
+}
+
+func (t *textWalker) renderTable(el Element) {
+	rows := collectTableRows(t, el.Children, false)
+	if len(rows) == 0 {
+		return
+	}
+	if t.wroteAny {
+		fmt.Fprint(t.w, "\n\n")
+	}
+	if t.opts.TextOnly || !t.opts.PrettyTables {
+		for _, r := range rows {
+			fmt.Fprintln(t.w, strings.Join(r.cells, "\t"))
+		}
+		t.wroteAny = true
+		return
+	}
+	writeASCIITable(t.w, rows, t.opts)
+	t.wroteAny = true
+}
+
+func collectTableRows(t *textWalker, children Blocks, inHeader bool) []textTableRow {
+	var rows []textTableRow
+	for _, c := range children {
+		el, ok := unwrapElement(c)
+		if !ok {
+			continue
+		}
+		switch el.Type {
+		case "thead":
+			rows = append(rows, collectTableRows(t, el.Children, true)...)
+		case "tbody":
+			rows = append(rows, collectTableRows(t, el.Children, inHeader)...)
+		case "tr":
+			rows = append(rows, collectRow(t, el, inHeader))
+		}
+	}
+	return rows
+}
+
+func collectRow(t *textWalker, el Element, inHeader bool) textTableRow {
+	row := textTableRow{header: inHeader}
+	for _, c := range el.Children {
+		cell, ok := unwrapElement(c)
+		if !ok {
+			continue
+		}
+		switch cell.Type {
+		case "th":
+			row.header = true
+			row.cells = append(row.cells, t.collectText(cell.Children))
+		case "td":
+			row.cells = append(row.cells, t.collectText(cell.Children))
+		}
+	}
+	return row
+}
+
+// unwrapElement resolves c down to its underlying Element, following
+// custom Block implementations through RenderHTML.
+func unwrapElement(c Block) (Element, bool) {
+	switch el := c.(type) {
+	case Element:
+		return el, true
+	case Block:
+		if el == nil {
+			return Element{}, false
+		}
+		if rendered := el.RenderHTML(); rendered != nil {
+			return unwrapElement(rendered)
+		}
+	}
+	return Element{}, false
+}
+
+func writeASCIITable(w io.Writer, rows []textTableRow, opts *TextOptions) {
+	numCols := 0
+	for _, r := range rows {
+		if len(r.cells) > numCols {
+			numCols = len(r.cells)
+		}
+	}
+	if numCols == 0 {
+		return
+	}
+
+	colWidth := opts.ColWidth
+	if colWidth <= 0 {
+		colWidth = defaultColWidth
+	}
+	widths := make([]int, numCols)
+	for _, r := range rows {
+		for i, cell := range r.cells {
+			w := utf8.RuneCountInString(cell)
+			if w > colWidth {
+				w = colWidth
+			}
+			if w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	colSep := opts.ColumnSeparator
+	if colSep == "" {
+		colSep = "|"
+	}
+	rowSep := opts.RowSeparator
+	if rowSep == "" {
+		rowSep = "-"
+	}
+	center := opts.CenterSeparator
+	if center == "" {
+		center = "+"
+	}
+
+	var rule strings.Builder
+	rule.WriteString(center)
+	for _, wd := range widths {
+		rule.WriteString(strings.Repeat(rowSep, wd+2))
+		rule.WriteString(center)
+	}
+
+	if opts.Borders&BorderTop != 0 {
+		fmt.Fprintln(w, rule.String())
+	}
+	for _, r := range rows {
+		align := AlignLeft
+		if r.header {
+			align = opts.HeaderAlignment
+			if align == AlignDefault {
+				align = AlignCenter
+			}
+		}
+		var line strings.Builder
+		if opts.Borders&BorderLeft != 0 {
+			line.WriteString(colSep)
+		} else {
+			line.WriteString(" ")
+		}
+		for i := 0; i < numCols; i++ {
+			var cell string
+			if i < len(r.cells) {
+				cell = r.cells[i]
+			}
+			line.WriteString(" ")
+			line.WriteString(padCell(cell, widths[i], align))
+			line.WriteString(" ")
+			if i < numCols-1 || opts.Borders&BorderRight != 0 {
+				line.WriteString(colSep)
+			}
+		}
+		fmt.Fprintln(w, line.String())
+		if r.header {
+			fmt.Fprintln(w, rule.String())
+		}
+	}
+	if opts.Borders&BorderBottom != 0 {
+		fmt.Fprintln(w, rule.String())
+	}
+}
+
+func padCell(s string, width int, align TextAlign) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		runes = runes[:width]
+		s = string(runes)
+	}
+	pad := width - len(runes)
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", pad) + s
+	case AlignCenter:
+		left := pad / 2
+		right := pad - left
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", right)
+	default:
+		return s + strings.Repeat(" ", pad)
+	}
+}
@@ -0,0 +1,76 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func renderTextString(t *testing.T, root Block, opts *TextOptions) string {
+	t.Helper()
+	var buf strings.Builder
+	if err := RenderText(root, &buf, opts); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRenderTextAnchorFormat(t *testing.T) {
+	a := A(Attr{{Key: "href", Value: "https://example.com"}}, Text("click"))
+	s := renderTextString(t, a, nil)
+	if s != "click (https://example.com)" {
+		t.Fatalf("unexpected anchor rendering: %q", s)
+	}
+}
+
+func TestRenderTextAnchorFormatURLTypedHref(t *testing.T) {
+	a := A(Attr{{Key: "href", Value: URL("https://example.com")}}, Text("click"))
+	s := renderTextString(t, a, nil)
+	if s != "click (https://example.com)" {
+		t.Fatalf("unexpected anchor rendering for URL-typed href: %q", s)
+	}
+}
+
+func TestRenderTextOmitLinks(t *testing.T) {
+	a := A(Attr{{Key: "href", Value: "https://example.com"}}, Text("click"))
+	s := renderTextString(t, a, &TextOptions{OmitLinks: true})
+	if strings.Contains(s, "https://example.com") {
+		t.Fatalf("expected href to be omitted, got: %q", s)
+	}
+}
+
+func TestRenderTextSuppressesScriptAndStyle(t *testing.T) {
+	root := Blocks{
+		Element{Type: "script", Children: Blocks{Text("alert(1)")}},
+		Element{Type: "style", Children: Blocks{Text("body{color:red}")}},
+		P(NoAttr, Text("visible")),
+	}
+	s := renderTextString(t, root, nil)
+	if strings.Contains(s, "alert(1)") || strings.Contains(s, "color:red") {
+		t.Fatalf("expected script/style contents suppressed, got: %q", s)
+	}
+	if !strings.Contains(s, "visible") {
+		t.Fatalf("expected paragraph text present, got: %q", s)
+	}
+}
+
+func TestRenderTextParagraphTableSeparation(t *testing.T) {
+	root := Blocks{
+		P(NoAttr, Text("intro")),
+		Table(NoAttr, Tbody(NoAttr, Tr(NoAttr, Td(NoAttr, Text("cell"))))),
+	}
+	s := renderTextString(t, root, nil)
+	if !strings.Contains(s, "intro\n\n") {
+		t.Fatalf("expected a blank line between paragraph and table, got: %q", s)
+	}
+}
+
+func TestRenderTextASCIITableRuneSafeTruncation(t *testing.T) {
+	root := Table(NoAttr, Tbody(NoAttr, Tr(NoAttr, Td(NoAttr, Text("こんにちは世界")))))
+	s := renderTextString(t, root, &TextOptions{PrettyTables: true, ColWidth: 3})
+	if strings.ContainsRune(s, '�') {
+		t.Fatalf("expected no UTF-8 replacement characters from truncation, got: %q", s)
+	}
+	if !strings.Contains(s, "こんに") {
+		t.Fatalf("expected cell truncated on rune boundaries, got: %q", s)
+	}
+}